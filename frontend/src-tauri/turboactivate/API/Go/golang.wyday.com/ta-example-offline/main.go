@@ -0,0 +1,58 @@
+// ta-example-offline walks through activating and deactivating
+// TurboActivate on a computer with no internet access, by generating a
+// request file that's uploaded out-of-band (e.g. from another computer,
+// via https://wyday.com/limelm/deactivate/) and applying the response
+// file that comes back.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"golang.wyday.com/turboactivate"
+)
+
+func main() {
+	//TODO: goto the version page at LimeLM and paste this GUID here
+	ta, err := turboactivate.NewTurboActivate("18324776654b3946fc44a5f3.49025204", "")
+
+	if err != nil {
+		panic(err)
+	}
+
+	isAct, err := ta.IsActivated()
+	if err != nil {
+		panic(err)
+	}
+
+	if !isAct {
+		const reqPath = "TurboActivateRequest.xml"
+
+		if err := ta.ActivateOfflineRequest(reqPath); err != nil {
+			panic(err)
+		}
+
+		fmt.Println("Wrote " + reqPath + ".")
+		fmt.Println("Upload this file at https://wyday.com/limelm/deactivate/ from a computer with internet access,")
+		fmt.Println("then save the response file it gives you as TurboActivateResponse.xml next to this program.")
+		fmt.Println("Press enter once you've done that.")
+
+		bufio.NewReader(os.Stdin).ReadString('\n')
+
+		if err := ta.ActivateOfflineResponse("TurboActivateResponse.xml"); err != nil {
+			panic(err)
+		}
+
+		fmt.Println("Activated offline!")
+	}
+
+	// To deactivate offline (e.g. before moving the license to another
+	// computer), write a deactivation request instead:
+	//
+	//   err := ta.DeactivateOfflineRequest("TurboDeactivateRequest.xml", true)
+	//
+	// and upload that file the same way.
+
+	fmt.Println("Hello world!")
+}
@@ -0,0 +1,117 @@
+package turboactivate
+
+import "time"
+
+// AdaptivePolicy configures IsGenuineAdaptive's reverification schedule.
+// Rather than a fixed daysBetweenChecks, the license is reverified once
+// the app has burned through RenewFraction of its current trust window
+// (the time between the last successful server sync and the point at
+// which that sync's validity runs out). This keeps short-lived licenses
+// (monthly subs, trial extensions) from going a fixed 90 days without
+// checking in, while long-lived licenses still aren't reverified more
+// often than necessary.
+type AdaptivePolicy struct {
+	// RenewFraction is how far into the trust window the app gets
+	// before triggering a reverification. Defaults to 2/3 if zero.
+	RenewFraction float64
+
+	// MinInterval/MaxInterval clamp the computed reverification
+	// interval so a very short or very long trust window doesn't push
+	// the schedule to an impractical extreme.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// GraceFraction is the fraction of the trust window that may
+	// elapse, past the scheduled reverification, on offline use before
+	// the license is treated as not genuine. Defaults to the same value
+	// as RenewFraction's complement (1-RenewFraction) if zero. The
+	// resulting grace period is clamped by MinInterval/MaxInterval too.
+	GraceFraction float64
+}
+
+const defaultRenewFraction = 2.0 / 3.0
+
+// trustWindow holds the metadata IsGenuineAdaptive needs to compute a
+// schedule: when the current trust window was established, and how
+// long it's meant to last.
+type trustWindow struct {
+	lastSync time.Time
+	length   time.Duration
+}
+
+func (p AdaptivePolicy) renewFraction() float64 {
+	if p.RenewFraction > 0 {
+		return p.RenewFraction
+	}
+	return defaultRenewFraction
+}
+
+func (p AdaptivePolicy) graceFraction() float64 {
+	if p.GraceFraction > 0 {
+		return p.GraceFraction
+	}
+	return 1 - p.renewFraction()
+}
+
+func (p AdaptivePolicy) clamp(d time.Duration) time.Duration {
+	if p.MinInterval > 0 && d < p.MinInterval {
+		d = p.MinInterval
+	}
+	if p.MaxInterval > 0 && d > p.MaxInterval {
+		d = p.MaxInterval
+	}
+	return d
+}
+
+// IsGenuineAdaptive is IsGenuineEx's adaptive-scheduling counterpart: it
+// computes daysBetweenChecks and gracePeriodDays from the license's
+// trust window and policy instead of using fixed values, then delegates
+// to IsGenuineEx, which both raises the grace/overdue notices and
+// demotes the result to IGRNotGenuine once reverification is overdue
+// past the grace period - so a short trust window actually shortens how
+// long an unreverified license stays genuine, not just when its notice
+// fires.
+func (ta *TurboActivate) IsGenuineAdaptive(policy AdaptivePolicy) (IGR, error) {
+	tw := ta.trustWindow()
+
+	renewIn := policy.clamp(time.Duration(float64(tw.length) * policy.renewFraction()))
+	grace := policy.clamp(time.Duration(float64(tw.length) * policy.graceFraction()))
+
+	daysBetweenChecks := uint32(renewIn / (24 * time.Hour))
+	gracePeriodDays := uint32(grace / (24 * time.Hour))
+	if daysBetweenChecks == 0 {
+		daysBetweenChecks = 1
+	}
+
+	return ta.IsGenuineEx(daysBetweenChecks, gracePeriodDays, true, false)
+}
+
+// trustWindow derives the current trust window from the lifetime set
+// via SetLicenseLifetime and the time of the last successful sync
+// (lastSyncTime), using a conservative 90-day default length when no
+// lifetime has been set. The lifetime is held in memory only: it isn't
+// part of CachedState, so it isn't saved to or restored from a signed
+// state store.
+func (ta *TurboActivate) trustWindow() trustWindow {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+
+	length := ta.licenseLifetime
+	if length <= 0 {
+		length = 90 * 24 * time.Hour
+	}
+
+	return trustWindow{
+		lastSync: ta.lastSyncTime,
+		length:   length,
+	}
+}
+
+// SetLicenseLifetime records how long the current trust window should
+// last (e.g. the license's issue-to-expiry span, or the server's
+// declared next-sync interval), for use by IsGenuineAdaptive.
+func (ta *TurboActivate) SetLicenseLifetime(d time.Duration) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.licenseLifetime = d
+}
@@ -0,0 +1,152 @@
+package turboactivate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdaptivePolicy_renewFractionDefault(t *testing.T) {
+	var p AdaptivePolicy
+	if got := p.renewFraction(); got != defaultRenewFraction {
+		t.Fatalf("renewFraction() = %v, want default %v", got, defaultRenewFraction)
+	}
+}
+
+func TestAdaptivePolicy_renewFractionExplicit(t *testing.T) {
+	p := AdaptivePolicy{RenewFraction: 0.5}
+	if got := p.renewFraction(); got != 0.5 {
+		t.Fatalf("renewFraction() = %v, want 0.5", got)
+	}
+}
+
+func TestAdaptivePolicy_graceFractionDefaultsToRenewComplement(t *testing.T) {
+	p := AdaptivePolicy{RenewFraction: 0.6}
+	want := 1 - 0.6
+	if got := p.graceFraction(); got != want {
+		t.Fatalf("graceFraction() = %v, want %v", got, want)
+	}
+}
+
+func TestAdaptivePolicy_graceFractionExplicit(t *testing.T) {
+	p := AdaptivePolicy{RenewFraction: 0.6, GraceFraction: 0.1}
+	if got := p.graceFraction(); got != 0.1 {
+		t.Fatalf("graceFraction() = %v, want 0.1", got)
+	}
+}
+
+func TestAdaptivePolicy_clamp(t *testing.T) {
+	p := AdaptivePolicy{MinInterval: 7 * 24 * time.Hour, MaxInterval: 60 * 24 * time.Hour}
+
+	if got := p.clamp(1 * 24 * time.Hour); got != p.MinInterval {
+		t.Fatalf("clamp(1d) = %v, want MinInterval %v", got, p.MinInterval)
+	}
+	if got := p.clamp(200 * 24 * time.Hour); got != p.MaxInterval {
+		t.Fatalf("clamp(200d) = %v, want MaxInterval %v", got, p.MaxInterval)
+	}
+	if got := p.clamp(30 * 24 * time.Hour); got != 30*24*time.Hour {
+		t.Fatalf("clamp(30d) = %v, want unchanged 30d", got)
+	}
+}
+
+func TestAdaptivePolicy_clampNoBoundsIsNoOp(t *testing.T) {
+	var p AdaptivePolicy
+	d := 123 * time.Hour
+	if got := p.clamp(d); got != d {
+		t.Fatalf("clamp() with no bounds = %v, want unchanged %v", got, d)
+	}
+}
+
+// newActivatedTestTA returns a handle that has already passed Activate,
+// so IsGenuineAdaptive has a genuine result to schedule around instead
+// of the IGRNotChecked zero value.
+func newActivatedTestTA(t *testing.T) *TurboActivate {
+	t.Helper()
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	return ta
+}
+
+func TestIsGenuineAdaptive_RaisesGracePeriodExpiringWithinGraceWindow(t *testing.T) {
+	ta := newActivatedTestTA(t)
+	ta.SetLicenseLifetime(9 * 24 * time.Hour) // renewIn=6d, grace=3d with default 2/3 fraction
+	ta.lastSyncTime = time.Now().Add(-7 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineAdaptive(AdaptivePolicy{})
+	if err != nil {
+		t.Fatalf("IsGenuineAdaptive: %v", err)
+	}
+	if genuine != IGRGenuine {
+		t.Fatalf("IsGenuineAdaptive() = %v, want IGRGenuine within the grace period", genuine)
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeGracePeriodExpiring {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeGracePeriodExpiring", pending)
+	}
+}
+
+func TestIsGenuineAdaptive_RaisesReverificationOverduePastGrace(t *testing.T) {
+	ta := newActivatedTestTA(t)
+	ta.SetLicenseLifetime(9 * 24 * time.Hour) // renewIn=6d, grace=3d with default 2/3 fraction
+	ta.lastSyncTime = time.Now().Add(-20 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineAdaptive(AdaptivePolicy{})
+	if err != nil {
+		t.Fatalf("IsGenuineAdaptive: %v", err)
+	}
+	if genuine != IGRNotGenuine {
+		t.Fatalf("IsGenuineAdaptive() = %v, want IGRNotGenuine once reverification is overdue past the grace period", genuine)
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeReverificationOverdue {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeReverificationOverdue", pending)
+	}
+}
+
+func TestIsGenuineAdaptive_NoNoticeWithinRenewWindow(t *testing.T) {
+	ta := newActivatedTestTA(t)
+	ta.SetLicenseLifetime(9 * 24 * time.Hour)
+	ta.lastSyncTime = time.Now().Add(-1 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineAdaptive(AdaptivePolicy{})
+	if err != nil {
+		t.Fatalf("IsGenuineAdaptive: %v", err)
+	}
+	if genuine != IGRGenuine {
+		t.Fatalf("IsGenuineAdaptive() = %v, want IGRGenuine", genuine)
+	}
+
+	if pending := ta.PendingNotices(); len(pending) != 0 {
+		t.Fatalf("PendingNotices = %+v, want none", pending)
+	}
+}
+
+func TestIsGenuineAdaptive_ShortLifetimeReverifiesSoonerThanDefault(t *testing.T) {
+	// A 9-day license (renewIn=6d, grace=3d) that hasn't synced in 20
+	// days must actually fail the genuine check, not just warn about
+	// it - unlike the 90-day default trust window, which would still
+	// be well within its own schedule at 20 days.
+	ta := newActivatedTestTA(t)
+	ta.lastSyncTime = time.Now().Add(-20 * 24 * time.Hour)
+
+	ta.SetLicenseLifetime(9 * 24 * time.Hour)
+	if genuine, err := ta.IsGenuineAdaptive(AdaptivePolicy{}); err != nil {
+		t.Fatalf("IsGenuineAdaptive: %v", err)
+	} else if genuine != IGRNotGenuine {
+		t.Fatalf("IsGenuineAdaptive() with a 9-day lifetime = %v, want IGRNotGenuine", genuine)
+	}
+
+	ta2 := newActivatedTestTA(t)
+	ta2.lastSyncTime = time.Now().Add(-20 * 24 * time.Hour)
+	if genuine, err := ta2.IsGenuineAdaptive(AdaptivePolicy{}); err != nil {
+		t.Fatalf("IsGenuineAdaptive: %v", err)
+	} else if genuine != IGRGenuine {
+		t.Fatalf("IsGenuineAdaptive() with the default 90-day lifetime = %v, want IGRGenuine", genuine)
+	}
+}
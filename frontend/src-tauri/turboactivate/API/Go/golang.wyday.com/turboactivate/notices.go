@@ -0,0 +1,162 @@
+package turboactivate
+
+import (
+	"sync"
+	"time"
+)
+
+// NoticeKind identifies the category of a pre-throttle LicenseNotice.
+type NoticeKind int
+
+const (
+	// NoticeGracePeriodExpiring fires while IsGenuineEx is relying on the
+	// grace period because the scheduled server reverification is
+	// overdue.
+	NoticeGracePeriodExpiring NoticeKind = iota
+	// NoticeReverificationOverdue fires once the grace period itself has
+	// been exceeded, in the same check that demotes the result to
+	// IGRNotGenuine.
+	NoticeReverificationOverdue
+	// NoticeTrialExpiring fires as a trial's remaining days run low.
+	NoticeTrialExpiring
+	// NoticeFeatureSetChanged fires when the last server sync reported
+	// IGRGenuineFeaturesChanged.
+	NoticeFeatureSetChanged
+	// NoticeStateTampered fires when the signed local state cache
+	// (see SetStateStore) fails signature verification on load.
+	NoticeStateTampered
+)
+
+// NoticeSeverity ranks how urgently a LicenseNotice should be surfaced.
+type NoticeSeverity int
+
+const (
+	SeverityInfo NoticeSeverity = iota
+	SeverityWarning
+	SeverityCritical
+)
+
+// LicenseNotice is a non-fatal warning about licensing state that is
+// about to degrade - e.g. a grace period ending soon - raised before the
+// app is actually throttled by IsGenuineEx/IsActivated. This lets
+// integrators show a heads-up UI instead of only reacting once things
+// break.
+type LicenseNotice struct {
+	Kind          NoticeKind
+	Severity      NoticeSeverity
+	DaysRemaining int
+	Message       string
+	FirstSeen     time.Time
+}
+
+// defaultNoticeThrottle is used for any notice kind that hasn't been
+// given an explicit interval via SetNoticeThrottle.
+const defaultNoticeThrottle = 24 * time.Hour
+
+// noticeState holds the per-kind throttle configuration and delivery
+// bookkeeping for a single TurboActivate handle.
+type noticeState struct {
+	mu sync.Mutex
+
+	ch        chan LicenseNotice
+	throttle  map[NoticeKind]time.Duration
+	lastSent  map[NoticeKind]time.Time
+	firstSeen map[NoticeKind]time.Time
+	pending   []LicenseNotice
+}
+
+func (ta *TurboActivate) notices() *noticeState {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if ta.noticeSt == nil {
+		ta.noticeSt = &noticeState{
+			ch:        make(chan LicenseNotice, 16),
+			throttle:  make(map[NoticeKind]time.Duration),
+			lastSent:  make(map[NoticeKind]time.Time),
+			firstSeen: make(map[NoticeKind]time.Time),
+		}
+	}
+	return ta.noticeSt
+}
+
+// Notices returns a channel on which LicenseNotice values are delivered
+// as they're raised. The channel is shared across calls to Notices;
+// callers should range over it from a single goroutine.
+func (ta *TurboActivate) Notices() <-chan LicenseNotice {
+	return ta.notices().ch
+}
+
+// PendingNotices returns the notices currently active (raised and not
+// yet superseded by a newer notice of the same kind), for callers that
+// prefer polling over reading the Notices channel.
+func (ta *TurboActivate) PendingNotices() []LicenseNotice {
+	ns := ta.notices()
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	out := make([]LicenseNotice, len(ns.pending))
+	copy(out, ns.pending)
+	return out
+}
+
+// SetNoticeThrottle limits how often a given notice kind is delivered,
+// so integrators can tune how often a heads-up UI is allowed to
+// interrupt the user. The default throttle is 24h per kind.
+func (ta *TurboActivate) SetNoticeThrottle(kind NoticeKind, interval time.Duration) {
+	ns := ta.notices()
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.throttle[kind] = interval
+}
+
+// raiseNotice is called internally, by IsGenuineEx/UseTrial and the
+// adaptive scheduler, whenever a pre-throttle condition is detected.
+// It's rate-limited per kind and records FirstSeen the first time a
+// kind is observed.
+func (ta *TurboActivate) raiseNotice(kind NoticeKind, severity NoticeSeverity, daysRemaining int, message string) {
+	ns := ta.notices()
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+
+	now := time.Now()
+	if _, ok := ns.firstSeen[kind]; !ok {
+		ns.firstSeen[kind] = now
+	}
+
+	throttle := ns.throttle[kind]
+	if throttle == 0 {
+		throttle = defaultNoticeThrottle
+	}
+	if last, ok := ns.lastSent[kind]; ok && now.Sub(last) < throttle {
+		return
+	}
+	ns.lastSent[kind] = now
+
+	n := LicenseNotice{
+		Kind:          kind,
+		Severity:      severity,
+		DaysRemaining: daysRemaining,
+		Message:       message,
+		FirstSeen:     ns.firstSeen[kind],
+	}
+
+	ns.pending = replacePending(ns.pending, n)
+
+	select {
+	case ns.ch <- n:
+	default:
+		// Nobody's draining the channel right now; PendingNotices()
+		// still reflects the latest state, so we drop rather than
+		// block the caller that triggered this check.
+	}
+}
+
+func replacePending(pending []LicenseNotice, n LicenseNotice) []LicenseNotice {
+	for i, p := range pending {
+		if p.Kind == n.Kind {
+			pending[i] = n
+			return pending
+		}
+	}
+	return append(pending, n)
+}
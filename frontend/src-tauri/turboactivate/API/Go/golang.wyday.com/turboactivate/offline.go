@@ -0,0 +1,38 @@
+package turboactivate
+
+import "fmt"
+
+// ActivateOfflineRequest writes a TurboActivate offline activation
+// request file (a `.TurboActivateRequest.xml`) to path. The caller
+// uploads that file out-of-band (e.g. via https://wyday.com/limelm/deactivate/)
+// and, once they receive a response file back, applies it with
+// ActivateOfflineResponse.
+func (ta *TurboActivate) ActivateOfflineRequest(path string) error {
+	if path == "" {
+		return fmt.Errorf("turboactivate: path is required")
+	}
+	return ta.nativeOfflineActivationRequest(path)
+}
+
+// ActivateOfflineResponse applies an offline activation response file
+// previously obtained for a request written by ActivateOfflineRequest,
+// completing activation without a direct connection to the LimeLM
+// servers.
+func (ta *TurboActivate) ActivateOfflineResponse(path string) error {
+	if path == "" {
+		return fmt.Errorf("turboactivate: path is required")
+	}
+	return ta.nativeOfflineActivationResponse(path)
+}
+
+// DeactivateOfflineRequest writes an offline deactivation request file
+// to path, for upload to LimeLM in environments without direct internet
+// access. If eraseKey is true, the stored product key is erased from
+// this computer once the deactivation request has been written, the
+// same as the online Deactivate(eraseKey=true) behavior.
+func (ta *TurboActivate) DeactivateOfflineRequest(path string, eraseKey bool) error {
+	if path == "" {
+		return fmt.Errorf("turboactivate: path is required")
+	}
+	return ta.nativeOfflineDeactivationRequest(path, eraseKey)
+}
@@ -0,0 +1,139 @@
+package turboactivate
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"os"
+	"time"
+)
+
+// errInvalidOfflineResponse is returned when an offline activation
+// response file doesn't look like a real TurboActivate response, or its
+// signature doesn't verify, so ActivateOfflineResponse fails closed
+// instead of activating on the strength of any file happening to exist
+// at the given path.
+var errInvalidOfflineResponse = errors.New("turboactivate: offline activation response is missing, malformed, or doesn't match this product")
+
+// errOfflineVerificationKeyNotConfigured is returned by
+// ActivateOfflineResponse when no key was supplied via
+// WithOfflineVerificationKey: without a pinned key, a response's
+// signature can't be verified, so it can't be trusted either.
+var errOfflineVerificationKeyNotConfigured = errors.New("turboactivate: offline activation requires WithOfflineVerificationKey to be configured")
+
+// These don't call the native TA_ActivationRequestToFile /
+// TA_ActivateFromFile / TA_DeactivationRequestToFile entry points: this
+// package writes the request files itself, as the XML documents defined
+// below, and verifies response files itself against the key pinned via
+// WithOfflineVerificationKey.
+
+type offlineActivationRequest struct {
+	XMLName     xml.Name `xml:"TurboActivateRequest"`
+	VersionGUID string   `xml:"VersionGUID"`
+	ProductKey  string   `xml:"ProductKey,omitempty"`
+}
+
+func (ta *TurboActivate) nativeOfflineActivationRequest(path string) error {
+	req := offlineActivationRequest{
+		VersionGUID: ta.versionGUID,
+		ProductKey:  ta.productKey,
+	}
+
+	data, err := xml.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// offlineActivationResponse is the structure this package expects an
+// offline activation response file to have: it must echo the request's
+// VersionGUID and carry a hex-encoded ECDSA signature, over that
+// VersionGUID, from the key pinned via WithOfflineVerificationKey.
+//
+// A genuine LimeLM offline response also binds the signature to this
+// computer's hardware fingerprint, which isn't modeled here - this
+// package only has the version-GUID binding to work with - so this is
+// weaker than the native library's own offline verification. It's
+// still a real cryptographic check: without the private key matching
+// the pinned public key, a forged or edited response fails signature
+// verification and is rejected.
+type offlineActivationResponse struct {
+	XMLName     xml.Name `xml:"TurboActivateResponse"`
+	VersionGUID string   `xml:"VersionGUID"`
+	Signature   string   `xml:"Signature"`
+}
+
+func (ta *TurboActivate) nativeOfflineActivationResponse(path string) error {
+	ta.mu.Lock()
+	pub := ta.offlineVerifyKey
+	ta.mu.Unlock()
+	if pub == nil {
+		return errOfflineVerificationKeyNotConfigured
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var resp offlineActivationResponse
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return errInvalidOfflineResponse
+	}
+	if resp.VersionGUID != ta.versionGUID {
+		return errInvalidOfflineResponse
+	}
+
+	sig, err := hex.DecodeString(resp.Signature)
+	if err != nil || len(sig) == 0 {
+		return errInvalidOfflineResponse
+	}
+
+	digest := sha256.Sum256([]byte(resp.VersionGUID))
+	if !ecdsa.VerifyASN1(pub, digest[:], sig) {
+		return errInvalidOfflineResponse
+	}
+
+	ta.mu.Lock()
+	ta.lastGenuineResult = IGRGenuine
+	ta.lastSyncTime = time.Now()
+	ta.mu.Unlock()
+
+	ta.persistState(IGRGenuine)
+
+	return nil
+}
+
+type offlineDeactivationRequest struct {
+	XMLName     xml.Name `xml:"TurboDeactivateRequest"`
+	VersionGUID string   `xml:"VersionGUID"`
+	EraseKey    bool     `xml:"EraseKey"`
+}
+
+func (ta *TurboActivate) nativeOfflineDeactivationRequest(path string, eraseKey bool) error {
+	req := offlineDeactivationRequest{
+		VersionGUID: ta.versionGUID,
+		EraseKey:    eraseKey,
+	}
+
+	data, err := xml.MarshalIndent(req, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+
+	if eraseKey {
+		ta.mu.Lock()
+		ta.productKey = ""
+		ta.mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package turboactivate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newOfflineTestTA(t *testing.T, pub *ecdsa.PublicKey) *TurboActivate {
+	t.Helper()
+	opts := []Option{}
+	if pub != nil {
+		opts = append(opts, WithOfflineVerificationKey(pub))
+	}
+	ta, err := NewTurboActivate("test-guid", "", opts...)
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	return ta
+}
+
+func signOfflineResponse(t *testing.T, key *ecdsa.PrivateKey, versionGUID string) string {
+	t.Helper()
+	digest := sha256.Sum256([]byte(versionGUID))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("SignASN1: %v", err)
+	}
+	return hex.EncodeToString(sig)
+}
+
+func TestActivateOfflineResponse_RequiresVerificationKey(t *testing.T) {
+	ta := newOfflineTestTA(t, nil)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	sig := signOfflineResponse(t, key, "test-guid")
+	body := fmt.Sprintf(`<TurboActivateResponse><VersionGUID>test-guid</VersionGUID><Signature>%s</Signature></TurboActivateResponse>`, sig)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err == nil {
+		t.Fatal("ActivateOfflineResponse succeeded with no WithOfflineVerificationKey configured")
+	}
+}
+
+func TestActivateOfflineResponse_RejectsUnrecognizedFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ta := newOfflineTestTA(t, &key.PublicKey)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	if err := os.WriteFile(path, []byte("not a real response"), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err == nil {
+		t.Fatal("ActivateOfflineResponse accepted an unrecognized file")
+	}
+
+	genuine, err := ta.IsActivated()
+	if err != nil {
+		t.Fatalf("IsActivated: %v", err)
+	}
+	if genuine {
+		t.Fatal("ActivateOfflineResponse activated the product from a garbage file")
+	}
+}
+
+func TestActivateOfflineResponse_RejectsEmptyFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ta := newOfflineTestTA(t, &key.PublicKey)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err == nil {
+		t.Fatal("ActivateOfflineResponse accepted an empty file")
+	}
+}
+
+func TestActivateOfflineResponse_RejectsMismatchedVersionGUID(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ta := newOfflineTestTA(t, &key.PublicKey)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	sig := signOfflineResponse(t, key, "some-other-product")
+	body := fmt.Sprintf(`<TurboActivateResponse><VersionGUID>some-other-product</VersionGUID><Signature>%s</Signature></TurboActivateResponse>`, sig)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err == nil {
+		t.Fatal("ActivateOfflineResponse accepted a response for a different product")
+	}
+}
+
+func TestActivateOfflineResponse_RejectsForgedSignature(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ta := newOfflineTestTA(t, &key.PublicKey)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	// Signed with a different key pair than the one pinned via
+	// WithOfflineVerificationKey - simulates a hand-crafted response
+	// that gets the VersionGUID right but can't produce a real
+	// signature without the vendor's private key.
+	forgedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	sig := signOfflineResponse(t, forgedKey, "test-guid")
+	body := fmt.Sprintf(`<TurboActivateResponse><VersionGUID>test-guid</VersionGUID><Signature>%s</Signature></TurboActivateResponse>`, sig)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err == nil {
+		t.Fatal("ActivateOfflineResponse accepted a response signed by the wrong key")
+	}
+}
+
+func TestActivateOfflineResponse_AcceptsWellFormedResponse(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	ta := newOfflineTestTA(t, &key.PublicKey)
+	path := filepath.Join(t.TempDir(), "TurboActivateResponse.xml")
+
+	sig := signOfflineResponse(t, key, "test-guid")
+	body := fmt.Sprintf(`<TurboActivateResponse><VersionGUID>test-guid</VersionGUID><Signature>%s</Signature></TurboActivateResponse>`, sig)
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if err := ta.ActivateOfflineResponse(path); err != nil {
+		t.Fatalf("ActivateOfflineResponse: %v", err)
+	}
+
+	genuine, err := ta.IsActivated()
+	if err != nil {
+		t.Fatalf("IsActivated: %v", err)
+	}
+	if !genuine {
+		t.Fatal("ActivateOfflineResponse didn't activate the product from a validly signed response")
+	}
+}
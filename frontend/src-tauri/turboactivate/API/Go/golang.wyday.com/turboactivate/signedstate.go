@@ -0,0 +1,170 @@
+package turboactivate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrTampered is returned by SignedStateStore.Load when the persisted
+// state's signature doesn't match its content, i.e. the file was edited
+// or the clock was rolled back against a monotonic field it covers.
+var ErrTampered = errors.New("turboactivate: signed state signature mismatch")
+
+// CachedState is the subset of activation/trial state this package
+// caches locally between server syncs, guarded against tampering by
+// SignedStateStore.
+type CachedState struct {
+	Activated      bool
+	GenuineResult  IGR
+	TrialDaysLeft  uint32
+	FeatureValues  map[string]string
+	LastServerSync time.Time
+}
+
+// SignedStateStore persists CachedState signed with an ECDSA key pair
+// so that editing the cache file, or rolling back its timestamps
+// without also forging a valid signature, is detectable. The default
+// FileSignedStateStore generates its signing key on first run and
+// stores it in a file alongside the state it signs - this protects
+// against naive edits and clock rollback, but NOT against an attacker
+// with the same filesystem access as the app (they can read the key
+// file just as easily as the state file). Integrators who need that
+// stronger guarantee should supply a KeyStore backed by an OS keystore
+// via NewFileSignedStateStoreWithKeyStore; none ships in this package.
+type SignedStateStore interface {
+	// Load reads and verifies the persisted state. ErrTampered is
+	// returned if verification fails; callers should treat
+	// that as "state unknown" and force an online reverification.
+	Load() (CachedState, error)
+
+	// Save signs and persists state, overwriting any previous value.
+	Save(state CachedState) error
+
+	// Rotate generates a new signing key pair, re-signs the most
+	// recently saved state with it, and discards the old key.
+	Rotate() error
+}
+
+// signedEnvelope is the on-disk representation: the state plus a
+// signature over its canonical JSON encoding. It deliberately does not
+// carry the public key used to verify it - that key is pinned
+// separately (see KeyStore) so that an attacker who edits the state
+// file can't just forge a new key pair to go with their edits.
+type signedEnvelope struct {
+	State CachedState
+	Sig   []byte
+}
+
+// KeyStore abstracts where a SignedStateStore's ECDSA private key
+// lives. FileSignedStateStore's default implementation keeps it in a
+// file; an OS-keystore-backed implementation can be substituted via
+// NewFileSignedStateStoreWithKeyStore for a stronger tamper-resistance
+// guarantee than the file default provides.
+type KeyStore interface {
+	// Load returns the stored key, or an error satisfying
+	// os.IsNotExist if none has been generated yet.
+	Load() (*ecdsa.PrivateKey, error)
+
+	// Save persists key, overwriting any previous value.
+	Save(key *ecdsa.PrivateKey) error
+}
+
+func canonicalize(state CachedState) ([]byte, error) {
+	return json.Marshal(state)
+}
+
+func signState(key *ecdsa.PrivateKey, state CachedState) (signedEnvelope, error) {
+	payload, err := canonicalize(state)
+	if err != nil {
+		return signedEnvelope{}, err
+	}
+
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		return signedEnvelope{}, err
+	}
+
+	return signedEnvelope{State: state, Sig: sig}, nil
+}
+
+// verifyEnvelope checks env's signature against pub, the pinned public
+// key for this install - never a key carried inside env itself, since
+// that would let an attacker forge a new key pair to go with edited
+// state.
+func verifyEnvelope(env signedEnvelope, pub *ecdsa.PublicKey) (CachedState, error) {
+	payload, err := canonicalize(env.State)
+	if err != nil {
+		return CachedState{}, err
+	}
+	digest := sha256.Sum256(payload)
+
+	if !ecdsa.VerifyASN1(pub, digest[:], env.Sig) {
+		return CachedState{}, ErrTampered
+	}
+
+	return env.State, nil
+}
+
+func generateKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// loadStateStore loads and verifies ta's signed state store, if one was
+// configured via WithStateStore. A verification failure discards
+// whatever genuine-check result was cached in memory, forcing the next
+// IsGenuineEx/IsGenuine call to be treated as never having checked, and
+// raises a NoticeStateTampered notice.
+func (ta *TurboActivate) loadStateStore() error {
+	ta.mu.Lock()
+	store := ta.stateStore
+	ta.mu.Unlock()
+	if store == nil {
+		return nil
+	}
+
+	state, err := store.Load()
+	if err != nil {
+		ta.mu.Lock()
+		ta.lastGenuineResult = IGRNotChecked
+		ta.mu.Unlock()
+		ta.raiseNotice(NoticeStateTampered, SeverityCritical, 0,
+			"local license cache failed signature verification; forcing online reverification")
+		return err
+	}
+
+	ta.mu.Lock()
+	ta.lastGenuineResult = state.GenuineResult
+	ta.lastSyncTime = state.LastServerSync
+	ta.mu.Unlock()
+	return nil
+}
+
+// persistState saves the current genuine-check result to ta's signed
+// state store, if one was configured via WithStateStore. Save errors
+// are not surfaced to IsGenuineEx/IsGenuine callers: the cache is a
+// convenience for faster startup, not the source of truth, so its
+// unavailability shouldn't turn a successful server check into a
+// failure.
+func (ta *TurboActivate) persistState(result IGR) {
+	ta.mu.Lock()
+	store := ta.stateStore
+	syncTime := ta.lastSyncTime
+	ta.mu.Unlock()
+	if store == nil {
+		return
+	}
+
+	trial, _ := ta.TrialInfo()
+	_ = store.Save(CachedState{
+		Activated:      result == IGRGenuine || result == IGRGenuineFeaturesChanged,
+		GenuineResult:  result,
+		TrialDaysLeft:  trial.DaysRemaining,
+		LastServerSync: syncTime,
+	})
+}
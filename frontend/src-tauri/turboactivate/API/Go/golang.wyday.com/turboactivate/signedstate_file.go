@@ -0,0 +1,158 @@
+package turboactivate
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+)
+
+// FileSignedStateStore is the default SignedStateStore: it keeps the
+// signed state envelope in one file, and gets its ECDSA private key
+// from a KeyStore (by default, filePathKeyStore - a sibling file next
+// to the state).
+type FileSignedStateStore struct {
+	statePath string
+	keys      KeyStore
+}
+
+// NewFileSignedStateStore returns a SignedStateStore that persists its
+// envelope at statePath and its signing key at keyPath.
+func NewFileSignedStateStore(statePath, keyPath string) *FileSignedStateStore {
+	return NewFileSignedStateStoreWithKeyStore(statePath, &filePathKeyStore{path: keyPath})
+}
+
+// NewFileSignedStateStoreWithKeyStore is like NewFileSignedStateStore
+// but takes the private key's storage explicitly, so integrators can
+// substitute an OS-keystore-backed KeyStore instead of the portable
+// file-based default.
+func NewFileSignedStateStoreWithKeyStore(statePath string, keys KeyStore) *FileSignedStateStore {
+	return &FileSignedStateStore{statePath: statePath, keys: keys}
+}
+
+func (s *FileSignedStateStore) loadOrCreateKey() (*ecdsa.PrivateKey, error) {
+	key, err := s.keys.Load()
+	if err == nil {
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	key, err = generateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keys.Save(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Load reads and verifies the persisted state. If no state has ever
+// been saved, it returns a zero-value CachedState and a nil error.
+func (s *FileSignedStateStore) Load() (CachedState, error) {
+	data, err := os.ReadFile(s.statePath)
+	if os.IsNotExist(err) {
+		return CachedState{}, nil
+	}
+	if err != nil {
+		return CachedState{}, err
+	}
+
+	key, err := s.loadOrCreateKey()
+	if err != nil {
+		return CachedState{}, err
+	}
+
+	var env signedEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return CachedState{}, ErrTampered
+	}
+
+	return verifyEnvelope(env, &key.PublicKey)
+}
+
+// Save signs state with the store's key (generating one on first use)
+// and writes it to statePath.
+func (s *FileSignedStateStore) Save(state CachedState) error {
+	key, err := s.loadOrCreateKey()
+	if err != nil {
+		return err
+	}
+
+	env, err := signState(key, state)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.statePath), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.statePath, data, 0o600)
+}
+
+// Rotate generates a new signing key, re-signs the last saved state
+// with it, and discards the old key.
+func (s *FileSignedStateStore) Rotate() error {
+	state, err := s.Load()
+	if err != nil && err != ErrTampered {
+		return err
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return err
+	}
+	if err := s.keys.Save(key); err != nil {
+		return err
+	}
+
+	env, err := signState(key, state)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.statePath, data, 0o600)
+}
+
+// filePathKeyStore is the portable default KeyStore: it keeps the
+// ECDSA private key PEM-encoded in a single file.
+type filePathKeyStore struct {
+	path string
+}
+
+func (k *filePathKeyStore) Load() (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(k.path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, os.ErrInvalid
+	}
+	return x509.ParseECPrivateKey(block.Bytes)
+}
+
+func (k *filePathKeyStore) Save(key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(k.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(k.path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600)
+}
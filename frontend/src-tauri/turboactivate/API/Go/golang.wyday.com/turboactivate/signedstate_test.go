@@ -0,0 +1,190 @@
+package turboactivate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T) (store *FileSignedStateStore, statePath, keyPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	statePath = filepath.Join(dir, "state.json")
+	keyPath = filepath.Join(dir, "key.pem")
+	return NewFileSignedStateStore(statePath, keyPath), statePath, keyPath
+}
+
+func TestFileSignedStateStore_SaveLoadRoundTrip(t *testing.T) {
+	store, _, _ := testStore(t)
+
+	want := CachedState{
+		Activated:      true,
+		GenuineResult:  IGRGenuine,
+		TrialDaysLeft:  7,
+		FeatureValues:  map[string]string{"tier": "pro"},
+		LastServerSync: time.Now().Truncate(time.Second),
+	}
+
+	if err := store.Save(want); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Activated != want.Activated || got.TrialDaysLeft != want.TrialDaysLeft {
+		t.Fatalf("Load returned %+v, want %+v", got, want)
+	}
+}
+
+func TestFileSignedStateStore_LoadEmpty(t *testing.T) {
+	store, _, _ := testStore(t)
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load on empty store: %v", err)
+	}
+	if got.Activated || got.TrialDaysLeft != 0 || len(got.FeatureValues) != 0 {
+		t.Fatalf("expected zero-value state, got %+v", got)
+	}
+}
+
+func TestFileSignedStateStore_DetectsCorruption(t *testing.T) {
+	store, statePath, _ := testStore(t)
+
+	if err := store.Save(CachedState{TrialDaysLeft: 3}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	// Flip a byte in the middle of the file to simulate tampering.
+	data[len(data)/2] ^= 0xff
+	if err := os.WriteFile(statePath, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := store.Load(); err != ErrTampered {
+		t.Fatalf("Load after corruption = %v, want ErrTampered", err)
+	}
+}
+
+func TestFileSignedStateStore_Rotate(t *testing.T) {
+	store, _, keyPath := testStore(t)
+
+	if err := store.Save(CachedState{TrialDaysLeft: 5}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	oldKey, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("ReadFile key: %v", err)
+	}
+
+	if err := store.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+
+	newKey, err := os.ReadFile(keyPath)
+	if err != nil {
+		t.Fatalf("ReadFile key after rotate: %v", err)
+	}
+	if string(oldKey) == string(newKey) {
+		t.Fatal("Rotate did not change the signing key")
+	}
+
+	got, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load after rotate: %v", err)
+	}
+	if got.TrialDaysLeft != 5 {
+		t.Fatalf("Load after rotate = %+v, state was not preserved", got)
+	}
+}
+
+func TestFileSignedStateStore_OldEnvelopeAfterRotateFailsVerification(t *testing.T) {
+	store, statePath, _ := testStore(t)
+
+	if err := store.Save(CachedState{TrialDaysLeft: 5}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	staleEnvelope, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if err := store.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+
+	// Replaying the pre-rotation envelope against the post-rotation key
+	// file should be indistinguishable from tampering.
+	if err := os.WriteFile(statePath, staleEnvelope, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := store.Load(); err != ErrTampered {
+		t.Fatalf("Load with stale envelope = %v, want ErrTampered", err)
+	}
+}
+
+func TestNewTurboActivate_LoadsVerifiedCacheFromStateStore(t *testing.T) {
+	store, _, _ := testStore(t)
+
+	if err := store.Save(CachedState{GenuineResult: IGRGenuine, TrialDaysLeft: 4}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	ta, err := NewTurboActivate("test-guid", "", WithStateStore(store))
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+
+	result, err := ta.IsGenuine()
+	if err != nil {
+		t.Fatalf("IsGenuine: %v", err)
+	}
+	if result != IGRGenuine {
+		t.Fatalf("IsGenuine = %v, want IGRGenuine (loaded from the verified cache)", result)
+	}
+}
+
+func TestNewTurboActivate_DiscardsTamperedCacheAndRaisesNotice(t *testing.T) {
+	store, statePath, _ := testStore(t)
+
+	if err := store.Save(CachedState{GenuineResult: IGRGenuine}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	data[len(data)/2] ^= 0xff
+	if err := os.WriteFile(statePath, data, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	ta, err := NewTurboActivate("test-guid", "", WithStateStore(store))
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+
+	result, err := ta.IsGenuine()
+	if err != nil {
+		t.Fatalf("IsGenuine: %v", err)
+	}
+	if result == IGRGenuine {
+		t.Fatal("IsGenuine trusted a tampered cache")
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeStateTampered {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeStateTampered", pending)
+	}
+}
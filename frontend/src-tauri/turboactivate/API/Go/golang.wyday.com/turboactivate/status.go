@@ -0,0 +1,144 @@
+package turboactivate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// StatusHandlerOptions configures StatusHandler.
+type StatusHandlerOptions struct {
+	// ExposeFeatures lists custom license feature names to include in
+	// the response, under FeatureValues. Feature names not in this list
+	// are never exposed, even if set on the license.
+	ExposeFeatures []string
+
+	// RequireHMACToken, if non-empty, is the shared secret the caller
+	// must sign the request with. The request must carry a
+	// X-TurboActivate-Token header equal to
+	// hex(HMAC-SHA256(RequireHMACToken, r.URL.Path)), so the endpoint
+	// can be safely exposed on internal networks without a separate
+	// auth layer.
+	RequireHMACToken string
+}
+
+// StatusResponse is the JSON document served by StatusHandler.
+type StatusResponse struct {
+	Activated       bool   `json:"activated"`
+	GenuineResult   string `json:"genuine_result"`
+	FeaturesChanged bool   `json:"features_changed"`
+
+	// GracePeriodRemainingDays is how many days are left before the
+	// grace period runs out, from a pending NoticeGracePeriodExpiring.
+	// Zero unless that notice is pending.
+	GracePeriodRemainingDays int `json:"grace_period_remaining_days"`
+
+	// ReverificationOverdueDays is how many days past the grace period
+	// reverification already is, from a pending NoticeReverificationOverdue.
+	// Zero unless that notice is pending - a non-zero value means the
+	// license has already failed its genuine check, unlike
+	// GracePeriodRemainingDays, which means it's still genuine but
+	// running out of time.
+	ReverificationOverdueDays int `json:"reverification_overdue_days"`
+
+	TrialDaysRemaining uint32            `json:"trial_days_remaining"`
+	IsOnTrial          bool              `json:"is_on_trial"`
+	LastServerSync     time.Time         `json:"last_server_sync"`
+	FeatureValues      map[string]string `json:"feature_values,omitempty"`
+}
+
+func igrString(r IGR) string {
+	switch r {
+	case IGRNotChecked:
+		return "not_checked"
+	case IGRGenuine:
+		return "genuine"
+	case IGRGenuineFeaturesChanged:
+		return "genuine_features_changed"
+	case IGRNotGenuine:
+		return "not_genuine"
+	case IGRNotGenuineInVM:
+		return "not_genuine_in_vm"
+	case IGRInternetError:
+		return "internet_error"
+	default:
+		return "unknown"
+	}
+}
+
+// StatusHandler returns an http.Handler that reports ta's license/trial
+// state as JSON, for use as a readiness probe or admin dashboard source
+// in long-running services that embed TurboActivate.
+func StatusHandler(ta *TurboActivate, opts StatusHandlerOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts.RequireHMACToken != "" && !validStatusToken(opts.RequireHMACToken, r) {
+			http.Error(w, "invalid or missing token", http.StatusUnauthorized)
+			return
+		}
+
+		resp := buildStatusResponse(ta, opts)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	})
+}
+
+func buildStatusResponse(ta *TurboActivate, opts StatusHandlerOptions) StatusResponse {
+	ta.mu.Lock()
+	genuine := ta.lastGenuineResult
+	lastSync := ta.lastSyncTime
+	ta.mu.Unlock()
+
+	trialInfo, _ := ta.TrialInfo()
+
+	resp := StatusResponse{
+		Activated:          genuine == IGRGenuine || genuine == IGRGenuineFeaturesChanged,
+		GenuineResult:      igrString(genuine),
+		FeaturesChanged:    genuine == IGRGenuineFeaturesChanged,
+		TrialDaysRemaining: trialInfo.DaysRemaining,
+		IsOnTrial:          trialInfo.Active,
+		LastServerSync:     lastSync,
+	}
+
+	for _, n := range ta.PendingNotices() {
+		switch n.Kind {
+		case NoticeGracePeriodExpiring:
+			resp.GracePeriodRemainingDays = n.DaysRemaining
+		case NoticeReverificationOverdue:
+			resp.ReverificationOverdueDays = n.DaysRemaining
+		}
+	}
+
+	if len(opts.ExposeFeatures) > 0 {
+		resp.FeatureValues = make(map[string]string, len(opts.ExposeFeatures))
+		for _, name := range opts.ExposeFeatures {
+			if v, err := ta.GetFeatureValue(name); err == nil {
+				resp.FeatureValues[name] = v
+			}
+		}
+	}
+
+	return resp
+}
+
+func validStatusToken(secret string, r *http.Request) bool {
+	got := r.Header.Get("X-TurboActivate-Token")
+	if got == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(r.URL.Path))
+	want := mac.Sum(nil)
+
+	gotBytes, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(gotBytes, want) == 1
+}
@@ -0,0 +1,136 @@
+package turboactivate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStatusHandler_ReportsTrialState(t *testing.T) {
+	ta := newTestTA(t)
+	ta.SetTrialManager(NewInProcessTrialManager(5))
+	if _, err := ta.UseTrial(TAUser, ""); err != nil {
+		t.Fatalf("UseTrial: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/status", nil)
+	StatusHandler(ta, StatusHandlerOptions{}).ServeHTTP(rec, req)
+
+	if rec.Code != 200 {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+
+	var resp StatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !resp.IsOnTrial || resp.TrialDaysRemaining != 5 {
+		t.Fatalf("resp = %+v, want IsOnTrial=true TrialDaysRemaining=5", resp)
+	}
+}
+
+func TestStatusHandler_RequiresValidToken(t *testing.T) {
+	ta := newTestTA(t)
+	opts := StatusHandlerOptions{RequireHMACToken: "s3cret"}
+	handler := StatusHandler(ta, opts)
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != 401 {
+		t.Fatalf("missing token: status = %d, want 401", rec.Code)
+	}
+
+	mac := hmac.New(sha256.New, []byte(opts.RequireHMACToken))
+	mac.Write([]byte("/status"))
+	req2 := httptest.NewRequest("GET", "/status", nil)
+	req2.Header.Set("X-TurboActivate-Token", hex.EncodeToString(mac.Sum(nil)))
+	rec2 := httptest.NewRecorder()
+	handler.ServeHTTP(rec2, req2)
+	if rec2.Code != 200 {
+		t.Fatalf("valid token: status = %d, want 200", rec2.Code)
+	}
+}
+
+func TestStatusHandler_DistinguishesGraceFromOverdue(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta.lastSyncTime = time.Now().Add(-7 * 24 * time.Hour)
+
+	// renewIn=6d, grace=3d with the default 2/3 fraction: 7 days stale
+	// lands within the grace window, not past it.
+	if _, err := ta.IsGenuineEx(6, 3, true, false); err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/status", nil)
+	StatusHandler(ta, StatusHandlerOptions{}).ServeHTTP(rec, req)
+
+	var resp StatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if resp.GracePeriodRemainingDays == 0 {
+		t.Fatal("GracePeriodRemainingDays = 0, want non-zero within the grace window")
+	}
+	if resp.ReverificationOverdueDays != 0 {
+		t.Fatalf("ReverificationOverdueDays = %d, want 0 while still within the grace window", resp.ReverificationOverdueDays)
+	}
+
+	ta2, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta2.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta2.lastSyncTime = time.Now().Add(-20 * 24 * time.Hour)
+	if _, err := ta2.IsGenuineEx(6, 3, true, false); err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+
+	rec2 := httptest.NewRecorder()
+	req2 := httptest.NewRequest("GET", "/status", nil)
+	StatusHandler(ta2, StatusHandlerOptions{}).ServeHTTP(rec2, req2)
+
+	var resp2 StatusResponse
+	if err := json.Unmarshal(rec2.Body.Bytes(), &resp2); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if resp2.ReverificationOverdueDays == 0 {
+		t.Fatal("ReverificationOverdueDays = 0, want non-zero once past the grace period")
+	}
+	if resp2.GracePeriodRemainingDays != 0 {
+		t.Fatalf("GracePeriodRemainingDays = %d, want 0 once already overdue", resp2.GracePeriodRemainingDays)
+	}
+}
+
+func TestStatusHandler_ExposesOnlySelectedFeatures(t *testing.T) {
+	ta := newTestTA(t)
+	ta.SetFeatureValue("tier", "pro")
+	ta.SetFeatureValue("seats", "10")
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/status", nil)
+	StatusHandler(ta, StatusHandlerOptions{ExposeFeatures: []string{"tier"}}).ServeHTTP(rec, req)
+
+	var resp StatusResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	// "seats" is set on the license but not listed in ExposeFeatures, so
+	// only "tier" may show up in the response.
+	if len(resp.FeatureValues) != 1 || resp.FeatureValues["tier"] != "pro" {
+		t.Fatalf("FeatureValues = %v, want map[tier:pro]", resp.FeatureValues)
+	}
+}
@@ -0,0 +1,123 @@
+package turboactivate
+
+// TrialInfo is a snapshot of a trial's current state, returned by
+// TrialManager.Info so callers don't have to reconstruct it from
+// UseTrial/TrialDaysRemaining results.
+type TrialInfo struct {
+	Active        bool
+	Verified      bool
+	DaysRemaining uint32
+}
+
+// TrialManager owns the lifecycle of a single trial: starting it,
+// reporting how much of it is left, extending it, and ending it early.
+// The default backend (see trial_native.go) keeps trial state in memory
+// rather than in TurboActivate's native verified-trial storage;
+// NewInProcessTrialManager provides an alternative for tests, whose
+// remaining days can be set directly.
+type TrialManager interface {
+	// UseTrial begins, or re-verifies, the trial described by flags.
+	// extraData is passed through to the server for verified trials.
+	UseTrial(flags TAFlags, extraData string) (bool, error)
+
+	// TrialDaysRemaining returns the number of days left in the trial
+	// described by flags.
+	TrialDaysRemaining(flags TAFlags) (uint32, error)
+
+	// ExtendTrial adds extraDays to the trial's remaining time.
+	ExtendTrial(extraDays uint32) error
+
+	// EndTrial ends the trial immediately, before its natural expiry.
+	EndTrial() error
+
+	// Info returns a snapshot of the trial's current state.
+	Info() (TrialInfo, error)
+}
+
+func (ta *TurboActivate) trialManager() TrialManager {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if ta.trials == nil {
+		ta.trials = &nativeTrialManager{ta: ta}
+	}
+	return ta.trials
+}
+
+// SetTrialManager overrides the TrialManager backend used by UseTrial,
+// TrialDaysRemaining, ExtendTrial, EndTrial, and TrialInfo. Tests should
+// install a NewInProcessTrialManager instead of exercising the native
+// backend.
+func (ta *TurboActivate) SetTrialManager(m TrialManager) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.trials = m
+}
+
+// UseTrial begins, or re-verifies, the trial described by flags.
+func (ta *TurboActivate) UseTrial(flags TAFlags, extraData string) (bool, error) {
+	active, err := ta.trialManager().UseTrial(flags, extraData)
+	if err != nil {
+		return false, err
+	}
+
+	info, infoErr := ta.trialManager().Info()
+	if infoErr == nil {
+		ta.emitTrialEvent(trialEventForUse(active, info), info)
+		ta.noticeAndEmitTrialExpiring(info)
+	}
+
+	return active, nil
+}
+
+// TrialDaysRemaining returns the number of days left in the trial
+// described by flags.
+func (ta *TurboActivate) TrialDaysRemaining(flags TAFlags) (uint32, error) {
+	return ta.trialManager().TrialDaysRemaining(flags)
+}
+
+// ExtendTrial adds extraDays to the trial's remaining time.
+func (ta *TurboActivate) ExtendTrial(extraDays uint32) error {
+	if err := ta.trialManager().ExtendTrial(extraDays); err != nil {
+		return err
+	}
+	if info, err := ta.trialManager().Info(); err == nil {
+		ta.emitTrialEvent(TrialExtended, info)
+	}
+	return nil
+}
+
+// EndTrial ends the trial immediately, before its natural expiry.
+func (ta *TurboActivate) EndTrial() error {
+	if err := ta.trialManager().EndTrial(); err != nil {
+		return err
+	}
+	if info, err := ta.trialManager().Info(); err == nil {
+		ta.emitTrialEvent(TrialExpired, info)
+	}
+	return nil
+}
+
+// TrialInfo returns a snapshot of the current trial's state.
+func (ta *TurboActivate) TrialInfo() (TrialInfo, error) {
+	return ta.trialManager().Info()
+}
+
+func trialEventForUse(active bool, info TrialInfo) TrialEvent {
+	if !active || info.DaysRemaining == 0 {
+		return TrialExpired
+	}
+	if info.Verified {
+		return TrialVerified
+	}
+	return TrialStarted
+}
+
+const trialExpiringThresholdDays = 3
+
+func (ta *TurboActivate) noticeAndEmitTrialExpiring(info TrialInfo) {
+	if info.Active && info.DaysRemaining > 0 && info.DaysRemaining <= trialExpiringThresholdDays {
+		ta.raiseNotice(NoticeTrialExpiring, SeverityWarning, int(info.DaysRemaining),
+			"the trial is expiring soon")
+		ta.emitTrialEvent(TrialExpiring, info)
+	}
+}
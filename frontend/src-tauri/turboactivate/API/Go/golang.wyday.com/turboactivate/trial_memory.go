@@ -0,0 +1,65 @@
+package turboactivate
+
+import "sync"
+
+// InProcessTrialManager is a TrialManager backed entirely by in-memory
+// state, for tests that exercise trial lifecycle logic (expiry
+// thresholds, telemetry, notices) without touching the native
+// TurboActivate library.
+type InProcessTrialManager struct {
+	mu       sync.Mutex
+	active   bool
+	verified bool
+	days     uint32
+}
+
+// NewInProcessTrialManager returns an InProcessTrialManager starting
+// with the given number of trial days available.
+func NewInProcessTrialManager(days uint32) *InProcessTrialManager {
+	return &InProcessTrialManager{days: days}
+}
+
+func (m *InProcessTrialManager) UseTrial(flags TAFlags, extraData string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.verified = flags&TAVerifiedTrial != 0
+	m.active = m.days > 0
+	return m.active, nil
+}
+
+func (m *InProcessTrialManager) TrialDaysRemaining(flags TAFlags) (uint32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.days, nil
+}
+
+func (m *InProcessTrialManager) ExtendTrial(extraDays uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.days += extraDays
+	m.active = true
+	return nil
+}
+
+func (m *InProcessTrialManager) EndTrial() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active = false
+	m.days = 0
+	return nil
+}
+
+func (m *InProcessTrialManager) Info() (TrialInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return TrialInfo{Active: m.active, Verified: m.verified, DaysRemaining: m.days}, nil
+}
+
+// SetDaysRemaining lets a test fast-forward the trial to a specific
+// number of remaining days without waiting on real time.
+func (m *InProcessTrialManager) SetDaysRemaining(days uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.days = days
+	m.active = days > 0
+}
@@ -0,0 +1,64 @@
+package turboactivate
+
+import "sync"
+
+// defaultTrialDays is the length of a fresh trial started through
+// nativeTrialManager, matching TurboActivate's LimeLM-configured default
+// trial length in the absence of a real cgo build to read it from.
+const defaultTrialDays = 30
+
+// nativeTrialManager is the default TrialManager. It keeps the trial's
+// state in memory and doesn't call TurboActivate's native verified-trial
+// storage (TA_UseTrial, TA_TrialDaysRemaining, and friends): a fresh
+// trial starts with defaultTrialDays, and nothing persists across
+// processes.
+type nativeTrialManager struct {
+	ta *TurboActivate
+
+	mu       sync.Mutex
+	active   bool
+	verified bool
+	days     uint32
+}
+
+func (m *nativeTrialManager) UseTrial(flags TAFlags, extraData string) (bool, error) {
+	// A real build delegates to TA_UseTrial via cgo; this snapshot
+	// tracks the resulting state so the rest of this package (Info,
+	// telemetry, notices) has something to observe.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.active {
+		m.days = defaultTrialDays
+	}
+	m.active = true
+	m.verified = flags&TAVerifiedTrial != 0
+	return m.active, nil
+}
+
+func (m *nativeTrialManager) TrialDaysRemaining(flags TAFlags) (uint32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.days, nil
+}
+
+func (m *nativeTrialManager) ExtendTrial(extraDays uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.days += extraDays
+	m.active = true
+	return nil
+}
+
+func (m *nativeTrialManager) EndTrial() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.active = false
+	m.days = 0
+	return nil
+}
+
+func (m *nativeTrialManager) Info() (TrialInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return TrialInfo{Active: m.active, Verified: m.verified, DaysRemaining: m.days}, nil
+}
@@ -0,0 +1,46 @@
+package turboactivate
+
+// TrialEvent identifies a point in a trial's lifecycle that
+// TrialTelemetry hooks fire on.
+type TrialEvent string
+
+const (
+	TrialStarted  TrialEvent = "trial_started"
+	TrialVerified TrialEvent = "trial_verified"
+	TrialExpiring TrialEvent = "trial_expiring"
+	TrialExpired  TrialEvent = "trial_expired"
+	TrialExtended TrialEvent = "trial_extended"
+)
+
+// TrialTelemetry receives structured trial lifecycle events, so
+// downstream apps can wire trial metrics into their existing
+// observability instead of polling TrialDaysRemaining in a loop.
+type TrialTelemetry interface {
+	OnTrialEvent(event TrialEvent, info TrialInfo)
+}
+
+// TrialTelemetryFunc adapts a plain function to TrialTelemetry.
+type TrialTelemetryFunc func(event TrialEvent, info TrialInfo)
+
+func (f TrialTelemetryFunc) OnTrialEvent(event TrialEvent, info TrialInfo) {
+	f(event, info)
+}
+
+// SetTrialTelemetry installs a hook that's called whenever this
+// TurboActivate handle's trial lifecycle advances (started, verified,
+// expiring, expired, extended).
+func (ta *TurboActivate) SetTrialTelemetry(t TrialTelemetry) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	ta.telemetry = t
+}
+
+func (ta *TurboActivate) emitTrialEvent(event TrialEvent, info TrialInfo) {
+	ta.mu.Lock()
+	t := ta.telemetry
+	ta.mu.Unlock()
+
+	if t != nil {
+		t.OnTrialEvent(event, info)
+	}
+}
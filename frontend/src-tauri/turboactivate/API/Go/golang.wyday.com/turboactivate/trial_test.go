@@ -0,0 +1,151 @@
+package turboactivate
+
+import "testing"
+
+type recordingTelemetry struct {
+	events []TrialEvent
+}
+
+func (r *recordingTelemetry) OnTrialEvent(event TrialEvent, info TrialInfo) {
+	r.events = append(r.events, event)
+}
+
+func newTestTA(t *testing.T) *TurboActivate {
+	t.Helper()
+	ta, err := NewTurboActivate("test-guid", "")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	return ta
+}
+
+func TestUseTrial_EmitsStartedThenVerified(t *testing.T) {
+	ta := newTestTA(t)
+	mgr := NewInProcessTrialManager(30)
+	ta.SetTrialManager(mgr)
+
+	rec := &recordingTelemetry{}
+	ta.SetTrialTelemetry(rec)
+
+	if _, err := ta.UseTrial(TAUser, ""); err != nil {
+		t.Fatalf("UseTrial: %v", err)
+	}
+	if _, err := ta.UseTrial(TAUser|TAVerifiedTrial, ""); err != nil {
+		t.Fatalf("UseTrial: %v", err)
+	}
+
+	want := []TrialEvent{TrialStarted, TrialVerified}
+	if len(rec.events) != len(want) {
+		t.Fatalf("events = %v, want %v", rec.events, want)
+	}
+	for i, e := range want {
+		if rec.events[i] != e {
+			t.Fatalf("events[%d] = %v, want %v", i, rec.events[i], e)
+		}
+	}
+}
+
+func TestUseTrial_RaisesExpiringNoticeNearEnd(t *testing.T) {
+	ta := newTestTA(t)
+	mgr := NewInProcessTrialManager(2)
+	ta.SetTrialManager(mgr)
+
+	rec := &recordingTelemetry{}
+	ta.SetTrialTelemetry(rec)
+
+	if _, err := ta.UseTrial(TAUser, ""); err != nil {
+		t.Fatalf("UseTrial: %v", err)
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeTrialExpiring {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeTrialExpiring", pending)
+	}
+	if pending[0].DaysRemaining != 2 {
+		t.Fatalf("DaysRemaining = %d, want 2", pending[0].DaysRemaining)
+	}
+
+	want := []TrialEvent{TrialStarted, TrialExpiring}
+	if len(rec.events) != len(want) {
+		t.Fatalf("events = %v, want %v", rec.events, want)
+	}
+	for i, e := range want {
+		if rec.events[i] != e {
+			t.Fatalf("events[%d] = %v, want %v", i, rec.events[i], e)
+		}
+	}
+}
+
+func TestEndTrial_EmitsExpired(t *testing.T) {
+	ta := newTestTA(t)
+	mgr := NewInProcessTrialManager(10)
+	ta.SetTrialManager(mgr)
+
+	rec := &recordingTelemetry{}
+	ta.SetTrialTelemetry(rec)
+
+	if err := ta.EndTrial(); err != nil {
+		t.Fatalf("EndTrial: %v", err)
+	}
+
+	info, err := ta.TrialInfo()
+	if err != nil {
+		t.Fatalf("TrialInfo: %v", err)
+	}
+	if info.Active {
+		t.Fatal("trial still active after EndTrial")
+	}
+	if len(rec.events) != 1 || rec.events[0] != TrialExpired {
+		t.Fatalf("events = %v, want [TrialExpired]", rec.events)
+	}
+}
+
+func TestUseTrial_NativeBackendStartsWithDaysRemaining(t *testing.T) {
+	// Exercises the default nativeTrialManager backend directly (not
+	// NewInProcessTrialManager), since that's what trialManager() lazily
+	// installs when no test override is set.
+	ta := newTestTA(t)
+
+	rec := &recordingTelemetry{}
+	ta.SetTrialTelemetry(rec)
+
+	if _, err := ta.UseTrial(TAUser, ""); err != nil {
+		t.Fatalf("UseTrial: %v", err)
+	}
+
+	days, err := ta.TrialDaysRemaining(TAUser)
+	if err != nil {
+		t.Fatalf("TrialDaysRemaining: %v", err)
+	}
+	if days == 0 {
+		t.Fatal("days = 0 immediately after starting a fresh trial")
+	}
+
+	if len(rec.events) != 1 || rec.events[0] != TrialStarted {
+		t.Fatalf("events = %v, want [TrialStarted]", rec.events)
+	}
+}
+
+func TestExtendTrial_EmitsExtended(t *testing.T) {
+	ta := newTestTA(t)
+	mgr := NewInProcessTrialManager(1)
+	ta.SetTrialManager(mgr)
+
+	rec := &recordingTelemetry{}
+	ta.SetTrialTelemetry(rec)
+
+	if err := ta.ExtendTrial(7); err != nil {
+		t.Fatalf("ExtendTrial: %v", err)
+	}
+
+	days, err := ta.TrialDaysRemaining(TAUser)
+	if err != nil {
+		t.Fatalf("TrialDaysRemaining: %v", err)
+	}
+	if days != 8 {
+		t.Fatalf("days = %d, want 8", days)
+	}
+	if len(rec.events) != 1 || rec.events[0] != TrialExtended {
+		t.Fatalf("events = %v, want [TrialExtended]", rec.events)
+	}
+}
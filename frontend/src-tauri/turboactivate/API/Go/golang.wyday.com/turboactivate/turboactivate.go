@@ -0,0 +1,265 @@
+// Package turboactivate is a Go API for the LimeLM/TurboActivate
+// licensing system (https://wyday.com/limelm/), covering activation,
+// genuine-check, trial, and custom license feature functionality. It is
+// pure Go: it keeps activation and trial state in memory and doesn't
+// call the native TurboActivate library or the LimeLM servers.
+package turboactivate
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TAFlags controls how trial data is stored and verified. See UseTrial.
+type TAFlags uint32
+
+const (
+	// TASystem stores trial data system-wide instead of per-user.
+	TASystem TAFlags = 1 << iota
+	// TAUser stores trial data for the current user only.
+	TAUser
+	// TAVerifiedTrial uses the server-verified (un-resetable) trial
+	// instead of the unverified trial: it requires an internet
+	// connection to start, but can't be reset by rolling back the
+	// system clock or reinstalling the app.
+	TAVerifiedTrial
+)
+
+// IGR is the result of a genuine check (IsGenuine/IsGenuineEx).
+type IGR int
+
+const (
+	// IGRNotChecked is the zero value of IGR: no genuine check has
+	// succeeded yet for this handle, e.g. because it was just
+	// constructed and never activated. It must never be treated the
+	// same as IGRGenuine.
+	IGRNotChecked IGR = iota
+	IGRGenuine
+	IGRGenuineFeaturesChanged
+	IGRNotGenuine
+	IGRNotGenuineInVM
+	IGRInternetError
+)
+
+// TurboActivate wraps a single product's activation handle, plus the
+// local state this package needs to track between calls (the most
+// recent genuine-check outcome, trial bookkeeping, and the extensions
+// layered on top of the native API elsewhere in this package).
+type TurboActivate struct {
+	versionGUID string
+	productKey  string
+
+	mu sync.Mutex
+
+	// lastGenuineResult/lastSyncTime cache the most recent outcome of
+	// IsGenuine/IsGenuineEx so the rest of this package doesn't need a
+	// fresh round trip to the native library just to know where things
+	// stand.
+	lastGenuineResult IGR
+	lastSyncTime      time.Time
+
+	// licenseLifetime is the trust-window length used by
+	// IsGenuineAdaptive (see adaptive.go), set via SetLicenseLifetime.
+	// It's held in memory only and isn't saved to the signed state store.
+	licenseLifetime time.Duration
+
+	// features holds custom license feature values set via
+	// SetFeatureValue. Activate doesn't populate it, so it's empty until
+	// SetFeatureValue is called.
+	features map[string]string
+
+	noticeSt *noticeState
+
+	trials    TrialManager
+	telemetry TrialTelemetry
+
+	stateStore SignedStateStore
+
+	// offlineVerifyKey pins the public key ActivateOfflineResponse
+	// verifies offline activation response signatures against. See
+	// WithOfflineVerificationKey.
+	offlineVerifyKey *ecdsa.PublicKey
+}
+
+// Option configures optional TurboActivate behavior at construction
+// time. See WithStateStore.
+type Option func(*TurboActivate)
+
+// WithStateStore wires a SignedStateStore into the handle: cached
+// activation/trial state is loaded and its signature verified before
+// NewTurboActivate returns, so callers never trust a tampered cache
+// even on their very first IsGenuineEx/IsGenuine call. A verification
+// failure doesn't fail construction - it discards the untrusted cache
+// (forcing a real online reverification) and raises a
+// NoticeStateTampered notice.
+func WithStateStore(store SignedStateStore) Option {
+	return func(ta *TurboActivate) {
+		ta.stateStore = store
+	}
+}
+
+// WithOfflineVerificationKey pins the ECDSA public key that
+// ActivateOfflineResponse must verify an offline activation response's
+// signature against. In a real LimeLM integration this is the
+// vendor's published offline-activation public key; without it,
+// ActivateOfflineResponse has no way to distinguish a genuine response
+// from a hand-crafted file and refuses to activate.
+func WithOfflineVerificationKey(pub *ecdsa.PublicKey) Option {
+	return func(ta *TurboActivate) {
+		ta.offlineVerifyKey = pub
+	}
+}
+
+// NewTurboActivate constructs a TurboActivate handle for the product
+// identified by versionGUID. productKey may be empty (e.g. for a
+// trial-only install); Activate uses the key given here and fails when
+// it's empty.
+func NewTurboActivate(versionGUID, productKey string, opts ...Option) (*TurboActivate, error) {
+	if versionGUID == "" {
+		return nil, errors.New("turboactivate: versionGUID is required")
+	}
+
+	ta := &TurboActivate{versionGUID: versionGUID, productKey: productKey}
+	for _, opt := range opts {
+		opt(ta)
+	}
+
+	if ta.stateStore != nil {
+		// Errors here mean the cache failed verification; loadStateStore
+		// has already discarded it and raised a tamper notice, so
+		// construction still succeeds with a clean, unchecked state.
+		_ = ta.loadStateStore()
+	}
+
+	return ta, nil
+}
+
+// Activate marks the handle activated using the product key supplied to
+// NewTurboActivate, without contacting the LimeLM servers. Besides
+// ActivateOfflineResponse and a verified cache loaded via WithStateStore,
+// it's the only way to move lastGenuineResult out of IGRNotChecked.
+// extraData is accepted for parity with UseTrial but isn't used.
+func (ta *TurboActivate) Activate(extraData string) (IGR, error) {
+	ta.mu.Lock()
+	key := ta.productKey
+	ta.mu.Unlock()
+	if key == "" {
+		return IGRNotGenuine, errors.New("turboactivate: no product key set")
+	}
+
+	// A real build delegates to TA_Activate via cgo; this snapshot marks
+	// the handle genuine so the rest of this package (IsGenuineEx,
+	// notices, adaptive scheduling, signed cache, status endpoint) has a
+	// real activation to observe, rather than only the offline-response
+	// path in offline_native.go.
+	ta.mu.Lock()
+	ta.lastGenuineResult = IGRGenuine
+	ta.lastSyncTime = time.Now()
+	ta.mu.Unlock()
+
+	ta.persistState(IGRGenuine)
+
+	return IGRGenuine, nil
+}
+
+// IsGenuineEx reports the cached genuine-check result on a schedule:
+// once daysBetweenChecks days have passed since the last sync it raises
+// NoticeGracePeriodExpiring, and once gracePeriodDays more have passed
+// it demotes a genuine result to IGRNotGenuine (see isGenuine). It
+// doesn't contact the LimeLM servers; skipOffline and
+// offlineShowInetErr are accepted but not used.
+func (ta *TurboActivate) IsGenuineEx(daysBetweenChecks, gracePeriodDays uint32, skipOffline, offlineShowInetErr bool) (IGR, error) {
+	return ta.isGenuine(daysBetweenChecks, gracePeriodDays)
+}
+
+// IsGenuine reports the cached genuine-check result with no schedule to
+// honor, and records the current time as the last sync, as if a fresh
+// server check had just succeeded. It doesn't contact the LimeLM
+// servers.
+func (ta *TurboActivate) IsGenuine() (IGR, error) {
+	return ta.isGenuine(0, 0)
+}
+
+// isGenuine is the shared implementation behind IsGenuine/IsGenuineEx.
+// daysBetweenChecks/gracePeriodDays of 0 mean "no schedule": the cached
+// result is reported as-is, as if a fresh server check had just
+// succeeded. A non-zero daysBetweenChecks raises NoticeGracePeriodExpiring
+// once the schedule is overdue, and NoticeReverificationOverdue once
+// gracePeriodDays has also elapsed - at which point a previously genuine
+// result is demoted to IGRNotGenuine, mirroring the native library's
+// behavior of failing a license that's gone too long without
+// reverifying, rather than trusting a stale cached result forever.
+func (ta *TurboActivate) isGenuine(daysBetweenChecks, gracePeriodDays uint32) (IGR, error) {
+	ta.mu.Lock()
+	result := ta.lastGenuineResult
+	lastSync := ta.lastSyncTime
+	ta.mu.Unlock()
+
+	if daysBetweenChecks > 0 && (result == IGRGenuine || result == IGRGenuineFeaturesChanged) {
+		renewAt := lastSync.Add(time.Duration(daysBetweenChecks) * 24 * time.Hour)
+		graceEnd := renewAt.Add(time.Duration(gracePeriodDays) * 24 * time.Hour)
+
+		switch now := time.Now(); {
+		case now.After(graceEnd):
+			overdueDays := int(now.Sub(graceEnd) / (24 * time.Hour))
+			ta.raiseNotice(NoticeReverificationOverdue, SeverityCritical, overdueDays,
+				"license reverification is overdue past the grace period")
+			result = IGRNotGenuine
+		case now.After(renewAt):
+			remaining := int(graceEnd.Sub(now) / (24 * time.Hour))
+			ta.raiseNotice(NoticeGracePeriodExpiring, SeverityWarning, remaining,
+				"license reverification is overdue; the grace period ends soon")
+		}
+	}
+
+	ta.mu.Lock()
+	ta.lastGenuineResult = result
+	if daysBetweenChecks == 0 {
+		ta.lastSyncTime = time.Now()
+	}
+	ta.mu.Unlock()
+
+	if result == IGRGenuineFeaturesChanged {
+		ta.raiseNotice(NoticeFeatureSetChanged, SeverityInfo, 0,
+			"the license's feature set changed on the last server sync")
+	}
+
+	ta.persistState(result)
+
+	return result, nil
+}
+
+// IsActivated reports whether the handle's most recent genuine-check
+// result is IGRGenuine or IGRGenuineFeaturesChanged. It turns false once
+// IsGenuineEx demotes an overdue license to IGRNotGenuine.
+func (ta *TurboActivate) IsActivated() (bool, error) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	return ta.lastGenuineResult == IGRGenuine || ta.lastGenuineResult == IGRGenuineFeaturesChanged, nil
+}
+
+// SetFeatureValue records a custom license feature value, which
+// GetFeatureValue and StatusHandler then report. Neither Activate nor
+// ActivateOfflineResponse populates features, so callers (tests,
+// offline activation) set them here.
+func (ta *TurboActivate) SetFeatureValue(name, value string) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	if ta.features == nil {
+		ta.features = make(map[string]string)
+	}
+	ta.features[name] = value
+}
+
+// GetFeatureValue returns the value of a custom license feature field.
+func (ta *TurboActivate) GetFeatureValue(name string) (string, error) {
+	ta.mu.Lock()
+	defer ta.mu.Unlock()
+	v, ok := ta.features[name]
+	if !ok {
+		return "", errors.New("turboactivate: feature not found")
+	}
+	return v, nil
+}
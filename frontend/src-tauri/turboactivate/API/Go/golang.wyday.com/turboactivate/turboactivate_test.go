@@ -0,0 +1,175 @@
+package turboactivate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTurboActivate_StartsNotChecked(t *testing.T) {
+	ta := newTestTA(t)
+
+	genuine, err := ta.IsGenuine()
+	if err != nil {
+		t.Fatalf("IsGenuine: %v", err)
+	}
+	if genuine != IGRNotChecked {
+		t.Fatalf("IsGenuine() = %v, want IGRNotChecked before any activation", genuine)
+	}
+
+	activated, err := ta.IsActivated()
+	if err != nil {
+		t.Fatalf("IsActivated: %v", err)
+	}
+	if activated {
+		t.Fatal("IsActivated() = true before any activation")
+	}
+}
+
+func TestActivate_RequiresProductKey(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+
+	if _, err := ta.Activate(""); err == nil {
+		t.Fatal("Activate succeeded with no product key")
+	}
+}
+
+func TestActivate_MarksGenuineAndActivated(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+
+	genuine, err := ta.Activate("")
+	if err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	if genuine != IGRGenuine {
+		t.Fatalf("Activate() = %v, want IGRGenuine", genuine)
+	}
+
+	activated, err := ta.IsActivated()
+	if err != nil {
+		t.Fatalf("IsActivated: %v", err)
+	}
+	if !activated {
+		t.Fatal("IsActivated() = false after Activate")
+	}
+}
+
+func TestIsGenuineEx_DemotesToNotGenuineOnceOverdue(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta.lastSyncTime = time.Now().Add(-10 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineEx(5, 1, true, false)
+	if err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+	if genuine != IGRNotGenuine {
+		t.Fatalf("IsGenuineEx() = %v, want IGRNotGenuine once past the grace period", genuine)
+	}
+
+	activated, err := ta.IsActivated()
+	if err != nil {
+		t.Fatalf("IsActivated: %v", err)
+	}
+	if activated {
+		t.Fatal("IsActivated() = true after the license went overdue")
+	}
+}
+
+func TestIsGenuineEx_StaysGenuineWithinSchedule(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta.lastSyncTime = time.Now().Add(-1 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineEx(5, 1, true, false)
+	if err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+	if genuine != IGRGenuine {
+		t.Fatalf("IsGenuineEx() = %v, want IGRGenuine within schedule", genuine)
+	}
+}
+
+func TestIsGenuineEx_RaisesGracePeriodExpiringWithinGraceWindow(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta.lastSyncTime = time.Now().Add(-6 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineEx(5, 3, true, false)
+	if err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+	if genuine != IGRGenuine {
+		t.Fatalf("IsGenuineEx() = %v, want IGRGenuine within the grace period", genuine)
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeGracePeriodExpiring {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeGracePeriodExpiring", pending)
+	}
+}
+
+func TestIsGenuineEx_RaisesReverificationOverduePastGrace(t *testing.T) {
+	ta, err := NewTurboActivate("test-guid", "TEST-KEY")
+	if err != nil {
+		t.Fatalf("NewTurboActivate: %v", err)
+	}
+	if _, err := ta.Activate(""); err != nil {
+		t.Fatalf("Activate: %v", err)
+	}
+	ta.lastSyncTime = time.Now().Add(-10 * 24 * time.Hour)
+
+	genuine, err := ta.IsGenuineEx(5, 1, true, false)
+	if err != nil {
+		t.Fatalf("IsGenuineEx: %v", err)
+	}
+	if genuine != IGRNotGenuine {
+		t.Fatalf("IsGenuineEx() = %v, want IGRNotGenuine once past the grace period", genuine)
+	}
+
+	pending := ta.PendingNotices()
+	if len(pending) != 1 || pending[0].Kind != NoticeReverificationOverdue {
+		t.Fatalf("PendingNotices = %+v, want a single NoticeReverificationOverdue", pending)
+	}
+}
+
+func TestFeatureValue_UnsetByDefault(t *testing.T) {
+	ta := newTestTA(t)
+
+	if _, err := ta.GetFeatureValue("tier"); err == nil {
+		t.Fatal("GetFeatureValue succeeded for a feature that was never set")
+	}
+}
+
+func TestFeatureValue_SetThenGet(t *testing.T) {
+	ta := newTestTA(t)
+	ta.SetFeatureValue("tier", "pro")
+
+	v, err := ta.GetFeatureValue("tier")
+	if err != nil {
+		t.Fatalf("GetFeatureValue: %v", err)
+	}
+	if v != "pro" {
+		t.Fatalf("GetFeatureValue() = %q, want %q", v, "pro")
+	}
+}